@@ -0,0 +1,119 @@
+package acme
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// hmacSourceSchema returns the hmac_source block used by
+// external_account_binding to resolve the EAB HMAC from somewhere other
+// than inline state. It supports generic secret stores only (file, env,
+// Vault). account_key_pem has no equivalent source block and is always
+// read inline.
+func hmacSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"file": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"path": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+				"env": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+				"vault": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"address": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"token": {
+								Type:      schema.TypeString,
+								Optional:  true,
+								Sensitive: true,
+							},
+							"secret_path": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"key_field": {
+								Type:     schema.TypeString,
+								Optional: true,
+								Default:  "hmac_base64",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resolveHMACSource reads the EAB HMAC out of the hmac_source block
+// (the raw value of a MaxItems: 1 TypeList schema field, as stored in
+// the external_account_binding map). It returns "" if no block is set.
+func resolveHMACSource(raw interface{}) (string, error) {
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 {
+		return "", nil
+	}
+
+	block := list[0].(map[string]interface{})
+
+	if f, ok := block["file"].([]interface{}); ok && len(f) > 0 {
+		fb := f[0].(map[string]interface{})
+		data, err := os.ReadFile(fb["path"].(string))
+		if err != nil {
+			return "", fmt.Errorf("reading EAB hmac from %s: %w", fb["path"].(string), err)
+		}
+
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	if e, ok := block["env"].([]interface{}); ok && len(e) > 0 {
+		eb := e[0].(map[string]interface{})
+		name := eb["name"].(string)
+		v := os.Getenv(name)
+		if v == "" {
+			return "", fmt.Errorf("environment variable %s is not set or empty", name)
+		}
+
+		return v, nil
+	}
+
+	if v, ok := block["vault"].([]interface{}); ok && len(v) > 0 {
+		vb := v[0].(map[string]interface{})
+		return fetchVaultField(vb)
+	}
+
+	return "", fmt.Errorf("hmac_source block is set but does not configure a backend")
+}
@@ -0,0 +1,216 @@
+package acme
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestResolveHMACSource(t *testing.T) {
+	t.Run("no block set", func(t *testing.T) {
+		got, err := resolveHMACSource(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "" {
+			t.Errorf("resolveHMACSource(nil) = %q, want \"\"", got)
+		}
+	})
+
+	t.Run("file backend", func(t *testing.T) {
+		f, err := os.CreateTemp(t.TempDir(), "hmac")
+		if err != nil {
+			t.Fatalf("creating temp file: %v", err)
+		}
+		if _, err := f.WriteString("file-hmac-secret\n"); err != nil {
+			t.Fatalf("writing temp file: %v", err)
+		}
+		f.Close()
+
+		block := []interface{}{
+			map[string]interface{}{
+				"file": []interface{}{
+					map[string]interface{}{"path": f.Name()},
+				},
+				"env":   []interface{}{},
+				"vault": []interface{}{},
+			},
+		}
+
+		got, err := resolveHMACSource(block)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "file-hmac-secret"; got != want {
+			t.Errorf("resolveHMACSource() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("env backend", func(t *testing.T) {
+		t.Setenv("TF_ACME_TEST_HMAC", "env-hmac-secret")
+
+		block := []interface{}{
+			map[string]interface{}{
+				"file": []interface{}{},
+				"env": []interface{}{
+					map[string]interface{}{"name": "TF_ACME_TEST_HMAC"},
+				},
+				"vault": []interface{}{},
+			},
+		}
+
+		got, err := resolveHMACSource(block)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "env-hmac-secret"; got != want {
+			t.Errorf("resolveHMACSource() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("env backend missing variable", func(t *testing.T) {
+		block := []interface{}{
+			map[string]interface{}{
+				"file": []interface{}{},
+				"env": []interface{}{
+					map[string]interface{}{"name": "TF_ACME_TEST_HMAC_UNSET"},
+				},
+				"vault": []interface{}{},
+			},
+		}
+
+		if _, err := resolveHMACSource(block); err == nil {
+			t.Error("expected an error for an unset environment variable, got nil")
+		}
+	})
+
+	t.Run("no backend configured", func(t *testing.T) {
+		block := []interface{}{
+			map[string]interface{}{
+				"file":  []interface{}{},
+				"env":   []interface{}{},
+				"vault": []interface{}{},
+			},
+		}
+
+		if _, err := resolveHMACSource(block); err == nil {
+			t.Error("expected an error when no backend is configured, got nil")
+		}
+	})
+}
+
+func TestFetchVaultField(t *testing.T) {
+	t.Run("kv v2 response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got, want := r.Header.Get("X-Vault-Token"), "test-token"; got != want {
+				t.Errorf("X-Vault-Token = %q, want %q", got, want)
+			}
+			if got, want := r.URL.Path, "/v1/secret/data/acme"; got != want {
+				t.Errorf("request path = %q, want %q", got, want)
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"data": map[string]interface{}{
+						"hmac_base64": "kv2-hmac-secret",
+					},
+				},
+			})
+		}))
+		defer server.Close()
+
+		block := map[string]interface{}{
+			"address":     server.URL,
+			"token":       "test-token",
+			"secret_path": "secret/data/acme",
+			"key_field":   "hmac_base64",
+		}
+
+		got, err := fetchVaultField(block)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "kv2-hmac-secret"; got != want {
+			t.Errorf("fetchVaultField() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("kv v1 response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"hmac_base64": "kv1-hmac-secret",
+				},
+			})
+		}))
+		defer server.Close()
+
+		block := map[string]interface{}{
+			"address":     server.URL,
+			"token":       "test-token",
+			"secret_path": "secret/acme",
+			"key_field":   "hmac_base64",
+		}
+
+		got, err := fetchVaultField(block)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "kv1-hmac-secret"; got != want {
+			t.Errorf("fetchVaultField() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{},
+			})
+		}))
+		defer server.Close()
+
+		block := map[string]interface{}{
+			"address":     server.URL,
+			"token":       "test-token",
+			"secret_path": "secret/acme",
+			"key_field":   "hmac_base64",
+		}
+
+		if _, err := fetchVaultField(block); err == nil {
+			t.Error("expected an error for a missing field, got nil")
+		}
+	})
+
+	t.Run("non-200 response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		block := map[string]interface{}{
+			"address":     server.URL,
+			"token":       "test-token",
+			"secret_path": "secret/acme",
+			"key_field":   "hmac_base64",
+		}
+
+		if _, err := fetchVaultField(block); err == nil {
+			t.Error("expected an error for a non-200 response, got nil")
+		}
+	})
+
+	t.Run("missing address and token", func(t *testing.T) {
+		block := map[string]interface{}{
+			"address":     "",
+			"token":       "",
+			"secret_path": "secret/acme",
+			"key_field":   "hmac_base64",
+		}
+
+		if _, err := fetchVaultField(block); err == nil {
+			t.Error("expected an error when address and token are unset, got nil")
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package acme
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// fetchVaultField reads a single field out of a HashiCorp Vault KV
+// secret, transparently handling both the KV v1 and KV v2 response
+// shapes. It speaks Vault's HTTP API directly rather than pulling in
+// the Vault SDK, since nothing else in this provider needs it.
+func fetchVaultField(block map[string]interface{}) (string, error) {
+	address := block["address"].(string)
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		return "", fmt.Errorf("vault address not set (configure address or VAULT_ADDR)")
+	}
+
+	token := block["token"].(string)
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if token == "" {
+		return "", fmt.Errorf("vault token not set (configure token or VAULT_TOKEN)")
+	}
+
+	secretPath := block["secret_path"].(string)
+	keyField := block["key_field"].(string)
+
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(address, "/"), strings.TrimPrefix(secretPath, "/"))
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("building vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetching %s from vault: %w", secretPath, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading vault response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned %d reading %s: %s", resp.StatusCode, secretPath, body)
+	}
+
+	var parsed struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", fmt.Errorf("decoding vault response for %s: %w", secretPath, err)
+	}
+
+	// KV v2 nests the actual secret fields under a second "data" key;
+	// KV v1 has them directly under the top-level "data". Try v2 first
+	// and fall back to v1.
+	fields := parsed.Data
+	if nested, ok := fields["data"].(map[string]interface{}); ok {
+		fields = nested
+	}
+
+	raw, ok := fields[keyField]
+	if !ok {
+		return "", fmt.Errorf("field %q not found in vault secret %s", keyField, secretPath)
+	}
+
+	value, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("field %q in vault secret %s is not a string", keyField, secretPath)
+	}
+
+	return value, nil
+}
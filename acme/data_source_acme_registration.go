@@ -0,0 +1,119 @@
+package acme
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceACMERegistration returns a data source that resolves an
+// existing ACME account from its key, for bootstrapping resources like
+// acme_certificate against an account that was registered out-of-band
+// (for example by certbot, the lego CLI, or an operator UI) without
+// importing an acme_registration resource for it.
+func dataSourceACMERegistration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceACMERegistrationRead,
+		Schema: map[string]*schema.Schema{
+			"account_key_pem": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"registration_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"contacts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// eab_kid is only populated when the CA echoes the EAB key
+			// ID back on the account; RFC 8555 does not require it, so
+			// most CAs leave it empty.
+			"eab_kid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceACMERegistrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, err := expandACMEClient(d, meta, true)
+	if err != nil {
+		if regGone(err, "") {
+			return diag.Errorf("no ACME account is registered for this key; use an acme_registration resource to create one")
+		}
+
+		return diag.FromErr(err)
+	}
+
+	reg, err := client.Registration.ResolveAccountByKey()
+	if err != nil {
+		if regGone(err, "") {
+			return diag.Errorf("no ACME account is registered for this key; use an acme_registration resource to create one")
+		}
+
+		return diag.FromErr(err)
+	}
+
+	d.SetId(reg.URI)
+
+	if err := d.Set("registration_url", reg.URI); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("contacts", reg.Body.Contact); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("status", reg.Body.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("eab_kid", eabKidFromAccount(reg.Body)))
+}
+
+// eabKidFromAccount extracts the EAB key ID the CA echoed back on the
+// account, if any. RFC 8555 does not require a CA to return
+// externalAccountBinding on a newAccount response, so this is best
+// effort and returns "" when the field is absent or not in the shape
+// this provider knows how to read.
+func eabKidFromAccount(account acme.Account) string {
+	if len(account.ExternalAccountBinding) == 0 {
+		return ""
+	}
+
+	var eab struct {
+		Protected string `json:"protected"`
+	}
+	if err := json.Unmarshal(account.ExternalAccountBinding, &eab); err != nil {
+		return ""
+	}
+
+	protected, err := base64.RawURLEncoding.DecodeString(eab.Protected)
+	if err != nil {
+		return ""
+	}
+
+	var header struct {
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(protected, &header); err != nil {
+		return ""
+	}
+
+	return header.Kid
+}
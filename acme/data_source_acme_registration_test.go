@@ -0,0 +1,75 @@
+package acme
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+)
+
+func TestEABKidFromAccount(t *testing.T) {
+	protectedHeader := func(kid string) string {
+		header, err := json.Marshal(struct {
+			Kid string `json:"kid"`
+		}{Kid: kid})
+		if err != nil {
+			t.Fatalf("marshaling protected header: %v", err)
+		}
+
+		return base64.RawURLEncoding.EncodeToString(header)
+	}
+
+	eabJSON := func(protected string) json.RawMessage {
+		body, err := json.Marshal(struct {
+			Protected string `json:"protected"`
+		}{Protected: protected})
+		if err != nil {
+			t.Fatalf("marshaling EAB body: %v", err)
+		}
+
+		return body
+	}
+
+	testCases := []struct {
+		name    string
+		account acme.Account
+		want    string
+	}{
+		{
+			name:    "no external account binding",
+			account: acme.Account{},
+			want:    "",
+		},
+		{
+			name: "kid present",
+			account: acme.Account{
+				ExternalAccountBinding: eabJSON(protectedHeader("kid-123")),
+			},
+			want: "kid-123",
+		},
+		{
+			name: "protected header is not valid base64url",
+			account: acme.Account{
+				ExternalAccountBinding: eabJSON("not-base64!!"),
+			},
+			want: "",
+		},
+		{
+			name: "external account binding is not the expected shape",
+			account: acme.Account{
+				ExternalAccountBinding: json.RawMessage(`"unexpected"`),
+			},
+			want: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := eabKidFromAccount(tc.account); got != tc.want {
+				t.Errorf("eabKidFromAccount() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
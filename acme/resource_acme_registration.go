@@ -3,11 +3,14 @@ package acme
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/registration"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // resourceACMERegistration returns the current version of the
@@ -18,18 +21,57 @@ func resourceACMERegistration() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceACMERegistrationCreate,
 		ReadContext:   resourceACMERegistrationRead,
+		UpdateContext: resourceACMERegistrationUpdate,
 		DeleteContext: resourceACMERegistrationDelete,
+		CustomizeDiff: resourceACMERegistrationCustomizeDiff,
 		Schema: map[string]*schema.Schema{
+			// TODO: account_key_pem remains ForceNew because key
+			// rollover is not implemented, not because rollover is
+			// undesirable. RFC 8555 §7.3.5 key rollover requires the
+			// ACME client to sign the new key with the old one and
+			// POST it to the account's key-change URL, but the
+			// vendored go-acme/lego/v4 client (registration.Registrar,
+			// as of v4.12.3) has no KeyChange or equivalent method to
+			// drive that exchange. Drop ForceNew once lego exposes
+			// one; until then, rotating the key has to go through
+			// delete/re-register.
 			"account_key_pem": {
 				Type:      schema.TypeString,
 				Required:  true,
 				ForceNew:  true,
 				Sensitive: true,
 			},
+			// email_address is kept as a deprecated alias for
+			// contacts. It is no longer ForceNew: changing it (or
+			// contacts) now drives an account update at the CA
+			// instead of a destroy/recreate.
 			"email_address": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:       schema.TypeString,
+				Optional:   true,
+				Deprecated: "Use contacts instead. email_address will be removed in a future major version.",
+			},
+			// contacts holds the account's contact. A bare email
+			// address and its "mailto:" form are treated the same.
+			// Other URI schemes (e.g. "tel:+12125551212") are not
+			// passed through: the value is always sent to the CA as
+			// a "mailto:" contact.
+			//
+			// NOTE: registration.Registrar.Register/UpdateRegistration
+			// (as vendored from go-acme/lego/v4 v4.12.3) only ever
+			// sends a single "mailto:" contact sourced from the ACME
+			// user's GetEmail(), with no way to pass a full contact
+			// list through the high-level registration API. Until
+			// lego exposes one, contacts is capped at a single entry,
+			// which resourceACMERegistrationCreate/Update mirror into
+			// email_address so it actually reaches the CA.
+			"contacts": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 			"external_account_binding": {
 				Type:     schema.TypeList,
@@ -46,10 +88,14 @@ func resourceACMERegistration() *schema.Resource {
 						},
 						"hmac_base64": {
 							Type:      schema.TypeString,
-							Required:  true,
+							Optional:  true,
 							Sensitive: true,
 							ForceNew:  true,
 						},
+						// hmac_source resolves the EAB HMAC from
+						// Vault, a file, or an environment variable
+						// instead of inline state.
+						"hmac_source": hmacSourceSchema(),
 					},
 				},
 			},
@@ -57,11 +103,69 @@ func resourceACMERegistration() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			// status surfaces the account's status at the CA
+			// (acme.Account.Status: "valid", "deactivated", or
+			// "revoked") as read back on every refresh, so plans can
+			// detect drift such as an account deactivated out-of-band.
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// on_destroy controls whether destroying this resource
+			// deactivates the account at the CA ("deactivate", the
+			// default and historical behavior) or only removes it
+			// from Terraform state, leaving the account valid at the
+			// CA ("retain"). "retain" is useful when the Terraform
+			// resource's lifecycle should be decoupled from the CA
+			// account, e.g. destroying and rebuilding a workspace
+			// without invalidating the account other resources still
+			// reference out-of-band.
+			"on_destroy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "deactivate",
+				ValidateFunc: validation.StringInSlice([]string{
+					"deactivate",
+					"retain",
+				}, false),
+			},
 		},
 	}
 }
 
+// contactEmail resolves the single contact lego is able to send to the
+// CA: email_address if set directly, otherwise the lone entry in
+// contacts (with any "mailto:" scheme stripped, since lego's registrar
+// prefixes GetEmail()'s value with it). Returns "" if neither is set.
+func contactEmail(d *schema.ResourceData) string {
+	if email := d.Get("email_address").(string); email != "" {
+		return email
+	}
+
+	contacts, ok := d.Get("contacts").([]interface{})
+	if !ok || len(contacts) == 0 {
+		return ""
+	}
+
+	contact, ok := contacts[0].(string)
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimPrefix(contact, "mailto:")
+}
+
 func resourceACMERegistrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// email_address is what expandACMEClient reads to build the ACME
+	// user lego registers with; mirror contacts into it so the single
+	// contact lego supports actually reaches the CA. See the NOTE on
+	// the contacts schema field.
+	if email := contactEmail(d); email != "" {
+		if err := d.Set("email_address", email); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// register and agree to the TOS
 	client, _, err := expandACMEClient(d, meta, false)
 	if err != nil {
@@ -71,10 +175,20 @@ func resourceACMERegistrationCreate(ctx context.Context, d *schema.ResourceData,
 	var reg *registration.Resource
 	// If EAB was enabled, register using EAB.
 	if v, ok := d.GetOk("external_account_binding"); ok {
+		eab := v.([]interface{})[0].(map[string]interface{})
+
+		hmac := eab["hmac_base64"].(string)
+		if hmac == "" {
+			hmac, err = resolveHMACSource(eab["hmac_source"])
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
 		reg, err = client.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
 			TermsOfServiceAgreed: true,
-			Kid:                  v.([]interface{})[0].(map[string]interface{})["key_id"].(string),
-			HmacEncoded:          v.([]interface{})[0].(map[string]interface{})["hmac_base64"].(string),
+			Kid:                  eab["key_id"].(string),
+			HmacEncoded:          hmac,
 		})
 	} else {
 		// Normal registration.
@@ -92,10 +206,51 @@ func resourceACMERegistrationCreate(ctx context.Context, d *schema.ResourceData,
 	return resourceACMERegistrationRead(ctx, d, meta)
 }
 
+func resourceACMERegistrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// See the NOTE on the contacts schema field: mirror contacts into
+	// email_address before expandACMEClient builds the ACME user, so a
+	// changed contact actually reaches the CA instead of only updating
+	// Terraform state.
+	if email := contactEmail(d); email != "" {
+		if err := d.Set("email_address", email); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	client, _, err := expandACMEClient(d, meta, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := client.Registration.UpdateRegistration(registration.RegisterOptions{
+		TermsOfServiceAgreed: true,
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceACMERegistrationRead(ctx, d, meta)
+}
+
+func resourceACMERegistrationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	contacts, ok := d.Get("contacts").([]interface{})
+	if (!ok || len(contacts) == 0) && d.Get("email_address").(string) == "" {
+		return fmt.Errorf("one of contacts or email_address must be set")
+	}
+
+	if v, ok := d.GetOk("external_account_binding"); ok {
+		eab := v.([]interface{})[0].(map[string]interface{})
+		if eab["hmac_base64"].(string) == "" && len(eab["hmac_source"].([]interface{})) == 0 {
+			return fmt.Errorf("one of external_account_binding.hmac_base64 or external_account_binding.hmac_source must be set")
+		}
+	}
+
+	return nil
+}
+
 func resourceACMERegistrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	_, user, err := expandACMEClient(d, meta, true)
 	if err != nil {
-		if regGone(err) {
+		if regGone(err, "") {
 			d.SetId("")
 			return nil
 		}
@@ -103,6 +258,15 @@ func resourceACMERegistrationRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("status", user.Registration.Body.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if regGone(nil, user.Registration.Body.Status) {
+		d.SetId("")
+		return nil
+	}
+
 	// save the reg
 	return diag.FromErr(saveACMERegistration(d, user.Registration))
 }
@@ -113,10 +277,30 @@ func resourceACMERegistrationDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if d.Get("on_destroy").(string) == "retain" {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "ACME account retained at the CA",
+				Detail: fmt.Sprintf("on_destroy is set to \"retain\": the account at %s was "+
+					"left valid at the CA and was only removed from Terraform state.",
+					d.Get("registration_url").(string)),
+			},
+		}
+	}
+
 	return diag.FromErr(client.Registration.DeleteRegistration())
 }
 
-func regGone(err error) bool {
+// regGone reports whether an ACME registration should be considered
+// gone from the CA, either because looking it up just failed with an
+// error the CA returns for a missing or deactivated account, or because
+// a successful lookup reports a terminal account status directly.
+func regGone(err error, status string) bool {
+	if status == acme.StatusDeactivated || status == acme.StatusRevoked {
+		return true
+	}
+
 	var e *acme.ProblemDetails
 	if !errors.As(err, &e) {
 		return false
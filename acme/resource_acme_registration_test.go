@@ -0,0 +1,125 @@
+package acme
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestContactEmail(t *testing.T) {
+	testCases := []struct {
+		name     string
+		email    string
+		contacts []interface{}
+		want     string
+	}{
+		{
+			name: "neither set",
+			want: "",
+		},
+		{
+			name:  "email_address set",
+			email: "ops@example.com",
+			want:  "ops@example.com",
+		},
+		{
+			name:     "contacts set, bare email",
+			contacts: []interface{}{"ops@example.com"},
+			want:     "ops@example.com",
+		},
+		{
+			name:     "contacts set, mailto scheme stripped",
+			contacts: []interface{}{"mailto:ops@example.com"},
+			want:     "ops@example.com",
+		},
+		{
+			name:     "email_address takes precedence over contacts",
+			email:    "legacy@example.com",
+			contacts: []interface{}{"mailto:ops@example.com"},
+			want:     "legacy@example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceACMERegistration().Schema, map[string]interface{}{
+				"email_address": tc.email,
+				"contacts":      tc.contacts,
+			})
+
+			if got := contactEmail(d); got != tc.want {
+				t.Errorf("contactEmail() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegGone(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status string
+		want   bool
+	}{
+		{
+			name: "nil error and empty status",
+			want: false,
+		},
+		{
+			name:   "status deactivated",
+			status: acme.StatusDeactivated,
+			want:   true,
+		},
+		{
+			name:   "status revoked",
+			status: acme.StatusRevoked,
+			want:   true,
+		},
+		{
+			name:   "status valid",
+			status: acme.StatusValid,
+			want:   false,
+		},
+		{
+			name: "accountDoesNotExist problem",
+			err: &acme.ProblemDetails{
+				HTTPStatus: 400,
+				Type:       "urn:ietf:params:acme:error:accountDoesNotExist",
+			},
+			want: true,
+		},
+		{
+			name: "unauthorized problem",
+			err: &acme.ProblemDetails{
+				HTTPStatus: 403,
+				Type:       "urn:ietf:params:acme:error:unauthorized",
+			},
+			want: true,
+		},
+		{
+			name: "unrelated problem",
+			err: &acme.ProblemDetails{
+				HTTPStatus: 500,
+				Type:       "urn:ietf:params:acme:error:serverInternal",
+			},
+			want: false,
+		},
+		{
+			name: "non-ACME error",
+			err:  errors.New("connection refused"),
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := regGone(tc.err, tc.status); got != tc.want {
+				t.Errorf("regGone(%v, %q) = %v, want %v", tc.err, tc.status, got, tc.want)
+			}
+		})
+	}
+}